@@ -0,0 +1,328 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package migrate runs versioned SQL migrations across many tenant
+// databases sharing one MySQL driver, reusing the tenant context plumbing
+// Driver.DoFilter already understands.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+
+	yalksmysql "github.com/yalks/mysql_drivers"
+)
+
+// schemaMigrationsTable tracks, per tenant database, which Migration
+// versions have already been applied.
+const schemaMigrationsTable = "schema_migrations"
+
+// Migration is a single versioned schema change, expressed either as a pair
+// of SQL statements (Up/Down) or as Go functions (UpFunc/DownFunc) for
+// changes plain SQL can't express, such as backfills.
+type Migration struct {
+	Version int64
+	Name    string
+
+	Up   string
+	Down string
+
+	UpFunc   func(ctx context.Context, tx gdb.TX) error
+	DownFunc func(ctx context.Context, tx gdb.TX) error
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithConcurrency bounds how many tenants Up migrates at once. The default
+// is 1 (fully sequential).
+func WithConcurrency(n int) Option {
+	return func(m *Migrator) {
+		if n > 0 {
+			m.concurrency = n
+		}
+	}
+}
+
+// WithDryRun, when true, makes Up print the rewritten SQL for each pending
+// migration (run through the driver's DoFilter, so tenant qualification and
+// sqlcommenter tags are visible) instead of executing it.
+func WithDryRun(dryRun bool) Option {
+	return func(m *Migrator) {
+		m.dryRun = dryRun
+	}
+}
+
+// WithLockTimeout bounds how long Up waits for a tenant's advisory lock
+// before giving up on that tenant. The default is 10 seconds.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTimeout = timeout
+	}
+}
+
+// Migrator applies Migrations to many tenant databases behind a single
+// Driver.
+type Migrator struct {
+	db         gdb.DB
+	migrations []Migration
+
+	concurrency int
+	dryRun      bool
+	lockTimeout time.Duration
+
+	mu        sync.Mutex
+	succeeded map[string]bool
+}
+
+// NewMigrator returns a Migrator that applies migrations, sorted by
+// Version, against db.
+func NewMigrator(db gdb.DB, migrations []Migration, opts ...Option) *Migrator {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	m := &Migrator{
+		db:          db,
+		migrations:  sorted,
+		concurrency: 1,
+		lockTimeout: 10 * time.Second,
+		succeeded:   make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Up migrates tenants to the latest version, with up to Migrator's
+// configured concurrency in flight at once. Tenants already migrated
+// successfully by a previous call to Up on this Migrator are skipped, so a
+// partial failure across many tenants can be retried by calling Up again
+// with the same tenants slice.
+func (m *Migrator) Up(ctx context.Context, tenants []string) error {
+	sem := make(chan struct{}, m.concurrency)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+
+	for _, tenant := range tenants {
+		if m.hasSucceeded(tenant) {
+			continue
+		}
+
+		tenant := tenant
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := m.upOne(ctx, tenant); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+				mu.Unlock()
+				return
+			}
+
+			m.markSucceeded(tenant)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// Succeeded reports the tenants Up has successfully migrated to date.
+func (m *Migrator) Succeeded() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tenants := make([]string, 0, len(m.succeeded))
+	for tenant := range m.succeeded {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+func (m *Migrator) hasSucceeded(tenant string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.succeeded[tenant]
+}
+
+func (m *Migrator) markSucceeded(tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.succeeded[tenant] = true
+}
+
+// upOne migrates a single tenant to the latest version under its advisory
+// lock. The lock, the ledger reads/writes, and every migration all run
+// against the single session m.db.Transaction pins for the call, since
+// GET_LOCK/RELEASE_LOCK are session-scoped and guard nothing if acquired on
+// one pooled connection while the migrations run on another.
+func (m *Migrator) upOne(ctx context.Context, tenant string) error {
+	ctx = yalksmysql.WithTenantDatabase(ctx, tenant)
+
+	return m.db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		unlock, err := m.acquireLock(tx, tenant)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		if err := m.ensureSchemaMigrationsTable(tx); err != nil {
+			return err
+		}
+
+		applied, err := m.appliedVersions(tx)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if applied[mig.Version] {
+				continue
+			}
+
+			if m.dryRun {
+				preview, err := m.renderUp(ctx, mig)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("-- tenant=%s version=%d name=%s\n%s\n", tenant, mig.Version, mig.Name, preview)
+				continue
+			}
+
+			if err := m.applyOne(ctx, mig); err != nil {
+				return fmt.Errorf("migration %d (%s): %w", mig.Version, mig.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// applyOne runs a single migration and records it as applied, in one
+// transaction nested (via savepoint) inside upOne's outer transaction, so a
+// failed ledger insert rolls back the migration's own writes too.
+func (m *Migrator) applyOne(ctx context.Context, mig Migration) error {
+	return m.db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+		if mig.UpFunc != nil {
+			if err := mig.UpFunc(ctx, tx); err != nil {
+				return err
+			}
+		} else if mig.Up != "" {
+			if _, err := tx.Exec(mig.Up); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Exec(
+			fmt.Sprintf("INSERT INTO `%s` (version, name, applied_at) VALUES (?, ?, ?)", schemaMigrationsTable),
+			mig.Version, mig.Name, time.Now(),
+		)
+		return err
+	})
+}
+
+// renderUp runs a migration's Up SQL through the driver's DoFilter so
+// dry-run output reflects tenant qualification and sqlcommenter tags.
+func (m *Migrator) renderUp(ctx context.Context, mig Migration) (string, error) {
+	if mig.Up == "" {
+		return fmt.Sprintf("-- Go migration %q has no SQL preview", mig.Name), nil
+	}
+
+	filterer, ok := m.db.(interface {
+		DoFilter(ctx context.Context, link gdb.Link, sql string, args []interface{}) (string, []interface{}, error)
+	})
+	if !ok {
+		return mig.Up, nil
+	}
+
+	rewritten, _, err := filterer.DoFilter(ctx, previewLink{}, mig.Up, nil)
+	return rewritten, err
+}
+
+// ensureSchemaMigrationsTable creates the tenant's migration ledger if it
+// doesn't already exist.
+func (m *Migrator) ensureSchemaMigrationsTable(tx gdb.TX) error {
+	_, err := tx.Exec(fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS `+"`%s`"+` (
+			version    BIGINT       NOT NULL PRIMARY KEY,
+			name       VARCHAR(255) NOT NULL,
+			applied_at DATETIME     NOT NULL
+		)`, schemaMigrationsTable,
+	))
+	return err
+}
+
+// appliedVersions returns the set of migration versions already recorded
+// for the tenant tx is scoped to.
+func (m *Migrator) appliedVersions(tx gdb.TX) (map[int64]bool, error) {
+	rows, err := tx.GetAll(fmt.Sprintf("SELECT version FROM `%s`", schemaMigrationsTable))
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		applied[row["version"].Int64()] = true
+	}
+	return applied, nil
+}
+
+// acquireLock takes MySQL's GET_LOCK advisory lock for tenant on tx's
+// session, returning a function that releases it on that same session.
+// GET_LOCK/RELEASE_LOCK are scoped to the MySQL connection that issued them,
+// so the lock must be acquired and released on the exact connection tx (and
+// everything upOne runs through it) holds for the duration of the call, not
+// a connection pulled fresh from the pool.
+func (m *Migrator) acquireLock(tx gdb.TX, tenant string) (func(), error) {
+	lockName := schemaMigrationsTable + ":" + tenant
+
+	acquired, err := tx.GetValue("SELECT GET_LOCK(?, ?)", lockName, int(m.lockTimeout.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	if acquired.Int64() != 1 {
+		return nil, fmt.Errorf("migrate: timed out waiting for advisory lock on tenant %s", tenant)
+	}
+
+	return func() {
+		_, _ = tx.Exec("SELECT RELEASE_LOCK(?)", lockName)
+	}, nil
+}
+
+// previewLink is a no-op gdb.Link used only so dry-run rendering can call
+// through Driver.DoFilter without acquiring a real connection.
+type previewLink struct{}
+
+func (previewLink) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("migrate: preview link does not execute statements")
+}
+
+func (previewLink) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, errors.New("migrate: preview link does not execute statements")
+}
+
+func (previewLink) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errors.New("migrate: preview link does not execute statements")
+}
+
+func (previewLink) IsOnMaster() bool    { return true }
+func (previewLink) IsTransaction() bool { return false }
@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package mysql implements gdb.Driver, which supports operations for MySQL.
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	_ "github.com/go-sql-driver/mysql"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// Driver is the driver for mysql database.
+type Driver struct {
+	*gdb.Core
+
+	// commenterOptions configures the sqlcommenter tags DoFilter injects
+	// into outgoing SQL statements. See SetCommenterOptions.
+	commenterOptions CommenterOptions
+
+	// rewriters is the statement rewriter pipeline DoFilter runs parsed
+	// statements through, in registration order. See Use.
+	rewriters []StatementRewriter
+
+	// replicaGroups holds the registered replica pools, keyed by group
+	// name. See SetReplicaGroups.
+	replicaGroups map[string]*ReplicaGroup
+
+	// tenantReplicaGroup pins a tenant database to a replica group name.
+	// See SetTenantReplicaGroup.
+	tenantReplicaGroup map[string]string
+
+	// digestCache memoizes statement fingerprints by raw SQL text. See
+	// digestFor.
+	digestCache     *digestCache
+	digestCacheOnce sync.Once
+
+	// metricsSink receives per-statement execution telemetry from
+	// DoCommit. See SetMetricsSink.
+	metricsSink MetricsSink
+}
+
+func init() {
+	if err := gdb.Register(`mysql`, New()); err != nil {
+		panic(err)
+	}
+}
+
+// New create and returns a driver that implements gdb.Driver, which supports
+// operations for MySQL.
+func New() gdb.Driver {
+	return &Driver{}
+}
+
+// New creates and returns a database object for mysql.
+// It implements the interface of gdb.Driver for extra database driver installation.
+func (d *Driver) New(core *gdb.Core, node *gdb.ConfigNode) (gdb.DB, error) {
+	return &Driver{
+		Core: core,
+	}, nil
+}
+
+// Open creates and returns the underlying *sql.DB object for the given
+// configuration node. It implements the interface of gdb.DB for extra
+// database driver installation.
+func (d *Driver) Open(config *gdb.ConfigNode) (*sql.DB, error) {
+	var source string
+	if config.Link != "" {
+		source = config.Link
+	} else {
+		source = fmt.Sprintf(
+			"%s:%s@tcp(%s:%s)/%s",
+			config.User, config.Pass, config.Host, config.Port, config.Name,
+		)
+		if config.Extra != "" {
+			source = fmt.Sprintf("%s?%s", source, config.Extra)
+		} else {
+			charset := config.Charset
+			if charset == "" {
+				charset = "utf8"
+			}
+			source = fmt.Sprintf("%s?charset=%s", source, charset)
+		}
+	}
+
+	return sql.Open("mysql", source)
+}
@@ -0,0 +1,245 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Context keys used to carry sqlcommenter tag values. They follow the same
+// plain string convention as the existing "tenant_database" key so callers
+// can set them with the standard context.WithValue.
+const (
+	ctxKeyApplication = "sqlcommenter_application"
+	ctxKeyController  = "sqlcommenter_controller"
+	ctxKeyAction      = "sqlcommenter_action"
+	ctxKeyRoute       = "sqlcommenter_route"
+	ctxKeyDBUser      = "sqlcommenter_db_user"
+)
+
+// CommenterOptions controls which sqlcommenter tags Driver.DoFilter injects
+// into outgoing SQL statements, and where the comment is placed.
+//
+// See https://google.github.io/sqlcommenter/ for the tag convention.
+type CommenterOptions struct {
+	// DisableTenantDB suppresses the tenant_db tag even when a tenant
+	// database is present on the context.
+	DisableTenantDB bool
+	// DisableTraceparent suppresses the W3C Trace Context traceparent tag.
+	DisableTraceparent bool
+	// DisableApplication suppresses the application/controller/action/route tags.
+	DisableApplication bool
+	// DisableDBUser suppresses the db_user tag.
+	DisableDBUser bool
+	// Leading places the comment before the statement instead of after it.
+	// The sqlcommenter spec recommends trailing placement, which is the default.
+	Leading bool
+	// SkipNonDML disables injection for statements other than
+	// SELECT/INSERT/UPDATE/DELETE/REPLACE/WITH.
+	SkipNonDML bool
+}
+
+// SetCommenterOptions configures sqlcommenter tag injection for the driver.
+// It is not safe to call concurrently with DoFilter.
+func (d *Driver) SetCommenterOptions(opts CommenterOptions) {
+	d.commenterOptions = opts
+}
+
+// WithApplication attaches the application name reported by the
+// sqlcommenter "application" tag.
+func WithApplication(ctx context.Context, application string) context.Context {
+	return context.WithValue(ctx, ctxKeyApplication, application)
+}
+
+// WithController attaches the controller/action/route reported by the
+// sqlcommenter "controller", "action" and "route" tags.
+func WithController(ctx context.Context, controller, action, route string) context.Context {
+	ctx = context.WithValue(ctx, ctxKeyController, controller)
+	ctx = context.WithValue(ctx, ctxKeyAction, action)
+	ctx = context.WithValue(ctx, ctxKeyRoute, route)
+	return ctx
+}
+
+// WithDBUser attaches the database user reported by the sqlcommenter
+// "db_user" tag.
+func WithDBUser(ctx context.Context, dbUser string) context.Context {
+	return context.WithValue(ctx, ctxKeyDBUser, dbUser)
+}
+
+// injectSqlComment builds the sqlcommenter tag set for ctx according to the
+// driver's CommenterOptions and appends (or prepends) it to sql. It skips
+// statements that already carry a trailing sqlcommenter-style comment and,
+// when SkipNonDML is set, statements other than SELECT/INSERT/UPDATE/DELETE/
+// REPLACE/WITH. Multi-statement batches are annotated per statement.
+func (d *Driver) injectSqlComment(ctx context.Context, sql string) string {
+	opts := d.commenterOptions
+
+	statements := splitStatements(sql)
+	for i, stmt := range statements {
+		trimmed := strings.TrimRight(strings.TrimSpace(stmt), ";")
+		if trimmed == "" {
+			continue
+		}
+		if hasSqlCommenterComment(trimmed) {
+			continue
+		}
+		if opts.SkipNonDML && !isDMLStatement(trimmed) {
+			continue
+		}
+
+		tags := d.commenterTags(ctx, opts)
+		if len(tags) == 0 {
+			continue
+		}
+
+		statements[i] = applyComment(stmt, tags, opts.Leading)
+	}
+
+	return strings.Join(statements, ";")
+}
+
+// commenterTags collects the enabled sqlcommenter tags for ctx, keyed by
+// their sqlcommenter tag name.
+func (d *Driver) commenterTags(ctx context.Context, opts CommenterOptions) map[string]string {
+	tags := make(map[string]string)
+
+	if !opts.DisableTenantDB {
+		if tenantDB, ok := ctx.Value(ctxKeyTenantDatabase).(string); ok && tenantDB != "" {
+			tags["tenant_db"] = tenantDB
+		}
+	}
+
+	if !opts.DisableTraceparent {
+		if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+			tags["traceparent"] = traceparent(spanCtx)
+		}
+	}
+
+	if !opts.DisableApplication {
+		if application, ok := ctx.Value(ctxKeyApplication).(string); ok && application != "" {
+			tags["application"] = application
+		}
+		if controller, ok := ctx.Value(ctxKeyController).(string); ok && controller != "" {
+			tags["controller"] = controller
+		}
+		if action, ok := ctx.Value(ctxKeyAction).(string); ok && action != "" {
+			tags["action"] = action
+		}
+		if route, ok := ctx.Value(ctxKeyRoute).(string); ok && route != "" {
+			tags["route"] = route
+		}
+	}
+
+	if !opts.DisableDBUser {
+		if dbUser, ok := ctx.Value(ctxKeyDBUser).(string); ok && dbUser != "" {
+			tags["db_user"] = dbUser
+		}
+	}
+
+	return tags
+}
+
+// traceparent assembles a W3C Trace Context traceparent value from an
+// OpenTelemetry span context.
+func traceparent(spanCtx trace.SpanContext) string {
+	flags := "00"
+	if spanCtx.IsSampled() {
+		flags = "01"
+	}
+	return "00-" + spanCtx.TraceID().String() + "-" + spanCtx.SpanID().String() + "-" + flags
+}
+
+// applyComment renders tags as a single sqlcommenter comment, keys sorted
+// lexicographically, and attaches it to stmt as configured by leading.
+func applyComment(stmt string, tags map[string]string, leading bool) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"='"+url.QueryEscape(tags[k])+"'")
+	}
+	comment := "/*" + strings.Join(pairs, ",") + "*/"
+
+	trimmed := strings.TrimSpace(stmt)
+	if leading {
+		return comment + " " + trimmed
+	}
+	return trimmed + " " + comment
+}
+
+// sqlCommenterTagPattern matches a sqlcommenter key='value' pair, e.g.
+// tenant_db='acme' or traceparent='00-...-01'.
+var sqlCommenterTagPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*='[^']*'(,[A-Za-z_][A-Za-z0-9_]*='[^']*')*$`)
+
+// hasSqlCommenterComment reports whether stmt already ends with a
+// sqlcommenter-style trailing comment, e.g. "... /*tenant_db='x'*/" or the
+// legacy "/* tenant_db:x */" tag this driver used to emit. It inspects the
+// trailing comment's contents rather than just its presence, so a statement
+// ending in an unrelated user-authored "/* note */" or a rendered optimizer
+// hint isn't mistaken for an already-tagged statement.
+func hasSqlCommenterComment(stmt string) bool {
+	trimmed := strings.TrimSpace(stmt)
+	if !strings.HasSuffix(trimmed, "*/") {
+		return false
+	}
+	open := strings.LastIndex(trimmed, "/*")
+	if open < 0 {
+		return false
+	}
+	body := strings.TrimSpace(trimmed[open+2 : len(trimmed)-2])
+	if strings.HasPrefix(body, "tenant_db:") {
+		return true
+	}
+	return sqlCommenterTagPattern.MatchString(body)
+}
+
+// isDMLStatement reports whether stmt is a statement type sqlcommenter tags
+// are conventionally attached to.
+func isDMLStatement(stmt string) bool {
+	upper := strings.ToUpper(strings.TrimSpace(stmt))
+	for _, prefix := range []string{"SELECT", "INSERT", "UPDATE", "DELETE", "REPLACE", "WITH"} {
+		if strings.HasPrefix(upper, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitStatements splits a (possibly multi-statement) SQL batch on
+// top-level ";" separators, ignoring semicolons inside string literals.
+func splitStatements(sql string) []string {
+	var (
+		statements []string
+		start      int
+		inString   rune
+	)
+	for i, r := range sql {
+		switch {
+		case inString != 0:
+			if r == inString {
+				inString = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			inString = r
+		case r == ';':
+			statements = append(statements, sql[start:i])
+			start = i + 1
+		}
+	}
+	statements = append(statements, sql[start:])
+	return statements
+}
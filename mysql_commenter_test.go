@@ -0,0 +1,104 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import "testing"
+
+func TestSplitStatements(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"single", "SELECT 1", []string{"SELECT 1"}},
+		{"two statements", "SELECT 1; SELECT 2", []string{"SELECT 1", " SELECT 2"}},
+		{
+			"semicolon inside string literal",
+			"SELECT 'a;b'; SELECT 2",
+			[]string{"SELECT 'a;b'", " SELECT 2"},
+		},
+		{
+			"semicolon inside backtick identifier",
+			"SELECT `a;b` FROM t",
+			[]string{"SELECT `a;b` FROM t"},
+		},
+		{"trailing semicolon", "SELECT 1;", []string{"SELECT 1", ""}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := splitStatements(c.in)
+			if len(got) != len(c.want) {
+				t.Fatalf("splitStatements(%q) = %q, want %q", c.in, got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("splitStatements(%q)[%d] = %q, want %q", c.in, i, got[i], c.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHasSqlCommenterComment(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want bool
+	}{
+		{"tagged trailing comment", "SELECT 1 /*tenant_db='acme'*/", true},
+		{"multiple tags", "SELECT 1 /*application='svc',tenant_db='acme'*/", true},
+		{"legacy tenant_db tag", "SELECT 1 /* tenant_db:acme */", true},
+		{"unrelated trailing comment", "SELECT 1 /* note */", false},
+		{"optimizer hint comment", "SELECT 1 /*+ MAX_EXECUTION_TIME(1000) */", false},
+		{"no comment", "SELECT 1", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := hasSqlCommenterComment(c.in); got != c.want {
+				t.Errorf("hasSqlCommenterComment(%q) = %v, want %v", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsDMLStatement(t *testing.T) {
+	cases := []struct {
+		in   string
+		want bool
+	}{
+		{"SELECT 1", true},
+		{"  insert into t values (1)", true},
+		{"UPDATE t SET a = 1", true},
+		{"DELETE FROM t", true},
+		{"REPLACE INTO t VALUES (1)", true},
+		{"WITH cte AS (SELECT 1) SELECT * FROM cte", true},
+		{"BEGIN", false},
+		{"SET autocommit = 0", false},
+	}
+
+	for _, c := range cases {
+		if got := isDMLStatement(c.in); got != c.want {
+			t.Errorf("isDMLStatement(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestApplyComment(t *testing.T) {
+	tags := map[string]string{"tenant_db": "acme", "application": "svc"}
+
+	trailing := applyComment("SELECT 1", tags, false)
+	if want := "SELECT 1 /*application='svc',tenant_db='acme'*/"; trailing != want {
+		t.Errorf("applyComment trailing = %q, want %q", trailing, want)
+	}
+
+	leading := applyComment("SELECT 1", tags, true)
+	if want := "/*application='svc',tenant_db='acme'*/ SELECT 1"; leading != want {
+		t.Errorf("applyComment leading = %q, want %q", leading, want)
+	}
+}
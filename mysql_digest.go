@@ -0,0 +1,272 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// digestCacheSize bounds the number of raw-SQL -> fingerprint entries kept
+// per Driver.
+const digestCacheSize = 4096
+
+// ctxKeyDigest carries the fingerprint digest of the statement currently
+// executing, so code further down the same call chain (logging, tracing)
+// can read it back via DigestFromContext.
+const ctxKeyDigest = "mysql_sql_digest"
+
+// DigestFromContext returns the fingerprint digest of the statement
+// currently executing on ctx, as set by Driver.DoCommit.
+func DigestFromContext(ctx context.Context) (string, bool) {
+	digest, ok := ctx.Value(ctxKeyDigest).(string)
+	return digest, ok
+}
+
+// MetricsSink receives per-statement execution telemetry computed from the
+// digest subsystem. See Driver.SetMetricsSink.
+type MetricsSink interface {
+	Observe(digest string, tenant string, elapsed time.Duration, rows int64, err error)
+}
+
+// SetMetricsSink registers the sink DoCommit reports statement telemetry
+// to. It is not safe to call concurrently with DoCommit.
+func (d *Driver) SetMetricsSink(sink MetricsSink) {
+	d.metricsSink = sink
+}
+
+// DoCommit wraps Core.DoCommit to fingerprint the executed statement,
+// dispatch it to a replica link when ctx prefers one, and, once execution
+// completes, report it to the registered MetricsSink.
+func (d *Driver) DoCommit(ctx context.Context, in gdb.DoCommitInput) (out gdb.DoCommitOutput, err error) {
+	digest := d.digestFor(in.Sql)
+	ctx = context.WithValue(ctx, ctxKeyDigest, digest)
+
+	// DoFilter can only change the SQL text, not the link it is given, so
+	// it can only annotate SQL with routing hints. DoCommit's in.Link is
+	// the link execution is actually about to run on, so this is where
+	// ReplicaPreferred reads actually get dispatched to a replica. Gated
+	// to SqlTypeQueryContext so plain writes (which go through
+	// DoExec/SqlTypeExecContext) are never misrouted; locking reads such
+	// as SELECT ... FOR UPDATE do arrive here, so the statement is parsed
+	// and only non-write statements are handed to PickLink.
+	if in.Type == gdb.SqlTypeQueryContext && readPreference(ctx) == ReplicaPreferred {
+		if parsed, perr := sqlparser.Parse(in.Sql); perr == nil && !isWriteStatement(parsed) {
+			in.Link = d.PickLink(ctx, in.Link)
+		}
+	}
+
+	start := time.Now()
+	out, err = d.Core.DoCommit(ctx, in)
+	elapsed := time.Since(start)
+
+	if d.metricsSink != nil {
+		tenant, _ := ctx.Value(ctxKeyTenantDatabase).(string)
+		d.metricsSink.Observe(digest, tenant, elapsed, commitRows(out), err)
+	}
+
+	return out, err
+}
+
+// commitRows extracts a row count from whichever result DoCommitOutput
+// carries: affected rows for exec statements, returned rows for queries.
+func commitRows(out gdb.DoCommitOutput) int64 {
+	if out.Result != nil {
+		if n, err := out.Result.RowsAffected(); err == nil {
+			return n
+		}
+	}
+	return int64(len(out.Records))
+}
+
+// digestFor returns the fingerprint for sql, consulting and populating the
+// driver's LRU cache so hot statements aren't re-normalized on every call.
+func (d *Driver) digestFor(sql string) string {
+	d.digestCacheOnce.Do(func() {
+		d.digestCache = newDigestCache(digestCacheSize)
+	})
+
+	if digest, ok := d.digestCache.get(sql); ok {
+		return digest
+	}
+
+	digest := fingerprintDigest(sql)
+	d.digestCache.put(sql, digest)
+	return digest
+}
+
+// fingerprintDigest returns the hex-encoded SHA-256 digest of sql's
+// fingerprint, à la performance_schema.events_statements_summary_by_digest.
+func fingerprintDigest(sql string) string {
+	sum := sha256.Sum256([]byte(fingerprint(sql)))
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint normalizes sql the way MySQL's statement digest does: comments
+// stripped, string and numeric literals replaced with '?', and whitespace
+// collapsed, so statements that only differ by their argument values
+// collapse to the same fingerprint. It's a lexical heuristic rather than a
+// full SQL tokenizer, which is sufficient for grouping statements for
+// metrics purposes.
+func fingerprint(sql string) string {
+	var out strings.Builder
+	runes := []rune(sql)
+	n := len(runes)
+	lastIdentChar := false
+
+	writeSpace := func() {
+		s := out.String()
+		if len(s) > 0 && s[len(s)-1] != ' ' {
+			out.WriteByte(' ')
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			quote := r
+			start := i
+			i++
+			for i < n {
+				if runes[i] == '\\' && i+1 < n {
+					i += 2
+					continue
+				}
+				if runes[i] == quote {
+					if i+1 < n && runes[i+1] == quote {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			if quote == '`' {
+				end := i
+				if end >= n {
+					end = n - 1
+				}
+				out.WriteString(string(runes[start : end+1]))
+				lastIdentChar = true
+			} else {
+				out.WriteByte('?')
+				lastIdentChar = false
+			}
+
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			end := indexFromRunes(runes, i+2, "*/")
+			if end < 0 {
+				i = n
+			} else {
+				i = end + 1
+			}
+
+		case (r == '-' && i+1 < n && runes[i+1] == '-') || r == '#':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+
+		case unicode.IsDigit(r) && !lastIdentChar:
+			j := i
+			for j < n && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			out.WriteByte('?')
+			i = j - 1
+			lastIdentChar = false
+
+		case unicode.IsSpace(r):
+			writeSpace()
+			lastIdentChar = false
+
+		default:
+			out.WriteRune(r)
+			lastIdentChar = unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		}
+	}
+
+	return strings.TrimSpace(out.String())
+}
+
+// indexFromRunes finds sep in runes starting at from, returning its index
+// into runes or -1 if not found.
+func indexFromRunes(runes []rune, from int, sep string) int {
+	if from > len(runes) {
+		return -1
+	}
+	idx := strings.Index(string(runes[from:]), sep)
+	if idx < 0 {
+		return -1
+	}
+	return from + idx
+}
+
+// digestCache is a small LRU cache mapping raw SQL text to its fingerprint
+// digest.
+type digestCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type digestCacheEntry struct {
+	sql    string
+	digest string
+}
+
+func newDigestCache(capacity int) *digestCache {
+	return &digestCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *digestCache) get(sql string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[sql]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*digestCacheEntry).digest, true
+}
+
+func (c *digestCache) put(sql, digest string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[sql]; ok {
+		el.Value.(*digestCacheEntry).digest = digest
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&digestCacheEntry{sql: sql, digest: digest})
+	c.entries[sql] = el
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*digestCacheEntry).sql)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import "testing"
+
+func TestFingerprint(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			"literal values collapse",
+			"SELECT * FROM t WHERE id = 1",
+			"SELECT * FROM t WHERE id = ?",
+		},
+		{
+			"string and numeric arguments differ but fingerprint matches",
+			"SELECT * FROM t WHERE name = 'bob' AND age = 42",
+			"SELECT * FROM t WHERE name = ? AND age = ?",
+		},
+		{
+			"backtick identifiers are preserved",
+			"SELECT `id` FROM `t` WHERE `id` = 1",
+			"SELECT `id` FROM `t` WHERE `id` = ?",
+		},
+		{
+			"escaped quote inside a string literal",
+			"SELECT * FROM t WHERE name = 'o\\'brien'",
+			"SELECT * FROM t WHERE name = ?",
+		},
+		{
+			"doubled quote inside a string literal",
+			"SELECT * FROM t WHERE name = 'o''brien'",
+			"SELECT * FROM t WHERE name = ?",
+		},
+		{
+			"block comment stripped",
+			"SELECT /* comment */ 1",
+			"SELECT ?",
+		},
+		{
+			"line comment stripped",
+			"SELECT 1 -- trailing comment\nFROM t",
+			"SELECT ? FROM t",
+		},
+		{
+			"insert into select",
+			"INSERT INTO a (id) SELECT id FROM b WHERE id > 10",
+			"INSERT INTO a (id) SELECT id FROM b WHERE id > ?",
+		},
+		{
+			"multi-table update",
+			"UPDATE a JOIN b ON a.id = b.id SET a.x = 1, b.y = 2 WHERE a.id = 5",
+			"UPDATE a JOIN b ON a.id = b.id SET a.x = ?, b.y = ? WHERE a.id = ?",
+		},
+		{
+			"whitespace collapsed",
+			"SELECT   1\n\tFROM   t",
+			"SELECT ? FROM t",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := fingerprint(c.in); got != c.want {
+				t.Errorf("fingerprint(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestFingerprintDigestIsStableAndArgumentAgnostic(t *testing.T) {
+	a := fingerprintDigest("SELECT * FROM t WHERE id = 1")
+	b := fingerprintDigest("SELECT * FROM t WHERE id = 2")
+	if a != b {
+		t.Errorf("fingerprintDigest should collapse differing literals: %q != %q", a, b)
+	}
+
+	c := fingerprintDigest("SELECT * FROM t WHERE id = 1")
+	if a != c {
+		t.Errorf("fingerprintDigest should be stable across calls: %q != %q", a, c)
+	}
+
+	d := fingerprintDigest("SELECT * FROM t WHERE name = 1")
+	if a == d {
+		t.Errorf("fingerprintDigest should differ for different statement shapes")
+	}
+}
+
+func TestDigestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newDigestCache(2)
+	c.put("sql-a", "digest-a")
+	c.put("sql-b", "digest-b")
+
+	if _, ok := c.get("sql-a"); !ok {
+		t.Fatalf("sql-a should still be cached")
+	}
+
+	c.put("sql-c", "digest-c")
+
+	if _, ok := c.get("sql-b"); ok {
+		t.Errorf("sql-b should have been evicted as least recently used")
+	}
+	if _, ok := c.get("sql-a"); !ok {
+		t.Errorf("sql-a should still be cached after being refreshed by get")
+	}
+	if _, ok := c.get("sql-c"); !ok {
+		t.Errorf("sql-c should be cached")
+	}
+}
+
+func TestDigestCachePutUpdatesExistingEntry(t *testing.T) {
+	c := newDigestCache(2)
+	c.put("sql-a", "digest-a")
+	c.put("sql-a", "digest-a-updated")
+
+	digest, ok := c.get("sql-a")
+	if !ok {
+		t.Fatalf("sql-a should be cached")
+	}
+	if digest != "digest-a-updated" {
+		t.Errorf("digest = %q, want %q", digest, "digest-a-updated")
+	}
+}
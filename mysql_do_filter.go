@@ -8,8 +8,6 @@ package mysql
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
 	"github.com/gogf/gf/v2/database/gdb"
 )
@@ -24,47 +22,17 @@ func (d *Driver) DoFilter(
 		return
 	}
 
-	// Extract tenant database from context
-	if tenantDB, ok := ctx.Value("tenant_database").(string); ok && tenantDB != "" {
-		// Inject tenant routing comment for ProxySQL
-		newSql = injectTenantComment(newSql, tenantDB)
+	// Run the statement through the rewriter pipeline registered via
+	// Driver.Use (tenant table qualification, read/write hints, ...).
+	newSql, err = d.runRewriters(ctx, link, newSql)
+	if err != nil {
+		return
 	}
 
+	// Annotate the statement with sqlcommenter tags (tenant routing,
+	// trace context, application metadata) as configured via
+	// Driver.SetCommenterOptions.
+	newSql = d.injectSqlComment(ctx, newSql)
+
 	return
 }
-
-// injectTenantComment injects tenant routing comment into SQL statement
-func injectTenantComment(sql, tenantDB string) string {
-	// Skip if comment already exists
-	if strings.Contains(sql, "/* tenant_db:") {
-		return sql
-	}
-
-	// Create tenant routing comment
-	comment := fmt.Sprintf("/* tenant_db:%s */ ", tenantDB)
-	
-	// Handle different SQL statement types
-	sql = strings.TrimSpace(sql)
-	
-	switch {
-	case strings.HasPrefix(strings.ToUpper(sql), "SELECT"):
-		return comment + sql
-	case strings.HasPrefix(strings.ToUpper(sql), "INSERT"):
-		return comment + sql
-	case strings.HasPrefix(strings.ToUpper(sql), "UPDATE"):
-		return comment + sql
-	case strings.HasPrefix(strings.ToUpper(sql), "DELETE"):
-		return comment + sql
-	case strings.HasPrefix(strings.ToUpper(sql), "REPLACE"):
-		return comment + sql
-	case strings.HasPrefix(strings.ToUpper(sql), "WITH"):
-		return comment + sql
-	default:
-		// For other statements (DDL, etc.), add comment after the first word
-		parts := strings.SplitN(sql, " ", 2)
-		if len(parts) >= 2 {
-			return parts[0] + " " + comment + parts[1]
-		}
-		return comment + sql
-	}
-}
\ No newline at end of file
@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetricsSink records statement digest telemetry as Prometheus
+// metrics, labeled by digest and tenant. It implements MetricsSink.
+type PrometheusMetricsSink struct {
+	duration *prometheus.HistogramVec
+	rows     *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+}
+
+// NewPrometheusMetricsSink creates a PrometheusMetricsSink and registers its
+// metrics against reg. A nil reg registers against the default Prometheus
+// registry.
+func NewPrometheusMetricsSink(reg prometheus.Registerer) *PrometheusMetricsSink {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	sink := &PrometheusMetricsSink{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "mysql_driver",
+			Name:      "statement_duration_seconds",
+			Help:      "Statement execution latency, labeled by fingerprint digest and tenant.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"digest", "tenant"}),
+		rows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mysql_driver",
+			Name:      "statement_rows_total",
+			Help:      "Rows returned or affected, labeled by fingerprint digest and tenant.",
+		}, []string{"digest", "tenant"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "mysql_driver",
+			Name:      "statement_errors_total",
+			Help:      "Statement execution errors, labeled by fingerprint digest and tenant.",
+		}, []string{"digest", "tenant"}),
+	}
+
+	reg.MustRegister(sink.duration, sink.rows, sink.errors)
+	return sink
+}
+
+// Observe implements MetricsSink.
+func (s *PrometheusMetricsSink) Observe(digest, tenant string, elapsed time.Duration, rows int64, err error) {
+	s.duration.WithLabelValues(digest, tenant).Observe(elapsed.Seconds())
+	if rows > 0 {
+		s.rows.WithLabelValues(digest, tenant).Add(float64(rows))
+	}
+	if err != nil {
+		s.errors.WithLabelValues(digest, tenant).Inc()
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// ReadPreference selects which MySQL role a read-only statement should
+// target. It has no effect on writes (INSERT/UPDATE/DELETE/REPLACE/DDL and
+// SELECT ... FOR UPDATE), which always target the primary.
+type ReadPreference int
+
+const (
+	// PrimaryPreferred routes reads to the primary. This is the default.
+	PrimaryPreferred ReadPreference = iota
+	// ReplicaPreferred routes reads to a replica, unless the statement is
+	// running on a link that's already inside a transaction.
+	ReplicaPreferred
+)
+
+const (
+	ctxKeyReadPreference = "mysql_read_preference"
+	ctxKeyMaxReplicaLag  = "mysql_max_replica_lag"
+)
+
+// WithReadPreference sets the read routing preference for statements issued
+// with ctx.
+func WithReadPreference(ctx context.Context, pref ReadPreference) context.Context {
+	return context.WithValue(ctx, ctxKeyReadPreference, pref)
+}
+
+// WithMaxReplicaLag caps how stale a ReplicaPreferred read may be. It is
+// surfaced as a `max_lag_ms` ProxySQL hint alongside `read_from=...`.
+func WithMaxReplicaLag(ctx context.Context, lag time.Duration) context.Context {
+	return context.WithValue(ctx, ctxKeyMaxReplicaLag, lag)
+}
+
+// readPreference returns the ReadPreference set on ctx, defaulting to
+// PrimaryPreferred.
+func readPreference(ctx context.Context) ReadPreference {
+	if pref, ok := ctx.Value(ctxKeyReadPreference).(ReadPreference); ok {
+		return pref
+	}
+	return PrimaryPreferred
+}
+
+// ReplicaGroup is a named pool of replica links tenants can be pinned to.
+type ReplicaGroup struct {
+	Name  string
+	Links []gdb.Link
+
+	roundRobin uint64
+}
+
+// next returns the next link in the group, round-robin.
+func (g *ReplicaGroup) next() gdb.Link {
+	i := atomic.AddUint64(&g.roundRobin, 1)
+	return g.Links[i%uint64(len(g.Links))]
+}
+
+// SetReplicaGroups registers the driver's replica pools, keyed by
+// ReplicaGroup.Name. It is not safe to call concurrently with PickLink.
+func (d *Driver) SetReplicaGroups(groups ...ReplicaGroup) {
+	d.replicaGroups = make(map[string]*ReplicaGroup, len(groups))
+	for i := range groups {
+		d.replicaGroups[groups[i].Name] = &groups[i]
+	}
+}
+
+// SetTenantReplicaGroup pins tenant to the named replica group. Tenants
+// without an entry use the "default" group.
+func (d *Driver) SetTenantReplicaGroup(tenant, group string) {
+	if d.tenantReplicaGroup == nil {
+		d.tenantReplicaGroup = make(map[string]string)
+	}
+	d.tenantReplicaGroup[tenant] = group
+}
+
+// replicaGroupName resolves the replica group ctx's tenant (if any) is
+// pinned to.
+func (d *Driver) replicaGroupName(ctx context.Context) string {
+	tenantDB, _ := ctx.Value(ctxKeyTenantDatabase).(string)
+	if group, ok := d.tenantReplicaGroup[tenantDB]; ok {
+		return group
+	}
+	return "default"
+}
+
+// PickLink returns the link a statement issued with ctx should actually
+// execute against. Driver.DoCommit calls this to dispatch ReplicaPreferred
+// reads to a registered replica group, since gdb has already selected link
+// by the time DoFilter runs and so can only annotate SQL with routing hints
+// for an external proxy (see ReplicaRoutingRewriter); DoCommit's in.Link is
+// the one that actually executes the statement. Callers that want to
+// dispatch to a local replica pool some other way can also call PickLink
+// directly. PickLink does not look at the statement itself: a link already
+// inside a transaction, or a ctx without ReplicaPreferred, gets link back
+// unchanged, and DoCommit only calls it for statements that are not writes.
+func (d *Driver) PickLink(ctx context.Context, link gdb.Link) gdb.Link {
+	if link.IsTransaction() || readPreference(ctx) != ReplicaPreferred {
+		return link
+	}
+
+	group, ok := d.replicaGroups[d.replicaGroupName(ctx)]
+	if !ok || len(group.Links) == 0 {
+		return link
+	}
+
+	return group.next()
+}
+
+// ReplicaRoutingRewriter hints replica read routing for ProxySQL-style
+// connection pools, honoring per-tenant replica group pins. Writes are left
+// untouched; ReadWriteHintRewriter already pins them to the primary.
+type ReplicaRoutingRewriter struct {
+	driver *Driver
+}
+
+// NewReplicaRoutingRewriter returns a StatementRewriter that hints replica
+// routing according to the driver's registered replica groups.
+func (d *Driver) NewReplicaRoutingRewriter() *ReplicaRoutingRewriter {
+	return &ReplicaRoutingRewriter{driver: d}
+}
+
+// Rewrite implements StatementRewriter.
+func (r *ReplicaRoutingRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	if isWriteStatement(stmt.Statement) {
+		return stmt, false, nil
+	}
+
+	inTransaction, _ := ctx.Value(ctxKeyInTransaction).(bool)
+	if inTransaction || readPreference(ctx) != ReplicaPreferred {
+		return stmt, false, nil
+	}
+
+	stmt.Hints = append(stmt.Hints, "read_from="+r.driver.replicaGroupName(ctx))
+	if lag, ok := ctx.Value(ctxKeyMaxReplicaLag).(time.Duration); ok && lag > 0 {
+		stmt.Hints = append(stmt.Hints, "max_lag_ms="+strconv.FormatInt(lag.Milliseconds(), 10))
+	}
+
+	return stmt, true, nil
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+func TestReplicaGroupNextRoundRobins(t *testing.T) {
+	a := noopLink{label: "a"}
+	b := noopLink{label: "b"}
+	group := &ReplicaGroup{Name: "default", Links: []gdb.Link{a, b}}
+
+	first := group.next()
+	second := group.next()
+	third := group.next()
+
+	if first == second {
+		t.Errorf("expected successive calls to rotate through links")
+	}
+	if first != third {
+		t.Errorf("expected round-robin to cycle back to the first link")
+	}
+}
+
+func TestReadWriteHintRewriterUnaffectedByReadPreference(t *testing.T) {
+	ctx := WithReadPreference(context.Background(), ReplicaPreferred)
+	r := NewReadWriteHintRewriter()
+
+	_, changed := rewriteSql(t, ctx, r, "SELECT * FROM users")
+	if changed {
+		t.Fatalf("read/write hinting should not react to read preference")
+	}
+}
+
+func TestReplicaRoutingRewriterHintsReplicaPreferredReads(t *testing.T) {
+	d := &Driver{}
+	d.SetReplicaGroups(ReplicaGroup{Name: "reporting", Links: nil})
+	d.SetTenantReplicaGroup("acme", "reporting")
+
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	ctx = WithReadPreference(ctx, ReplicaPreferred)
+	ctx = WithMaxReplicaLag(ctx, 500*time.Millisecond)
+
+	got, changed := rewriteSql(t, ctx, d.NewReplicaRoutingRewriter(), "SELECT * FROM orders")
+	if !changed {
+		t.Fatalf("expected change for a ReplicaPreferred read")
+	}
+	want := "select /*+ read_from=reporting max_lag_ms=500 */ * from orders"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplicaRoutingRewriterLeavesWritesAlone(t *testing.T) {
+	d := &Driver{}
+	ctx := WithReadPreference(context.Background(), ReplicaPreferred)
+
+	got, changed := rewriteSql(t, ctx, d.NewReplicaRoutingRewriter(), "UPDATE orders SET status = 1")
+	if changed {
+		t.Fatalf("writes should never be hinted toward a replica")
+	}
+	if want := "update orders set `status` = 1"; got != want {
+		t.Errorf("got %q, want statement returned unmodified %q", got, want)
+	}
+}
+
+func TestReplicaRoutingRewriterDefaultsToPrimaryPreferred(t *testing.T) {
+	d := &Driver{}
+
+	_, changed := rewriteSql(t, context.Background(), d.NewReplicaRoutingRewriter(), "SELECT * FROM orders")
+	if changed {
+		t.Fatalf("expected no change without an explicit ReplicaPreferred preference")
+	}
+}
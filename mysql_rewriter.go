@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// ctxKeyInTransaction carries whether the link a statement is executing on
+// is already inside a transaction. runRewriters sets it for the duration of
+// the pipeline so rewriters like ReplicaRoutingRewriter can see it without
+// StatementRewriter needing a gdb.Link parameter of its own.
+const ctxKeyInTransaction = "mysql_link_in_transaction"
+
+// ParsedStmt is a parsed SQL statement carried through the Driver's
+// StatementRewriter pipeline. Rewriters mutate Statement in place (or swap
+// it for a new one) to change the query DoFilter ultimately sends to MySQL;
+// Hints carries ProxySQL/optimizer hint fragments that are rendered as a
+// single `/*+ ... */` comment right after the statement's first keyword,
+// since those are positional rather than part of the statement grammar.
+type ParsedStmt struct {
+	Statement sqlparser.Statement
+	Hints     []string
+}
+
+// String renders the statement, with any accumulated hints prepended as a
+// single `/*+ ... */` comment immediately after the statement's first
+// keyword.
+func (p ParsedStmt) String() string {
+	sql := sqlparser.String(p.Statement)
+	if len(p.Hints) == 0 {
+		return sql
+	}
+	hint := "/*+ " + strings.Join(p.Hints, " ") + " */"
+	parts := strings.SplitN(sql, " ", 2)
+	if len(parts) < 2 {
+		return sql + " " + hint
+	}
+	return parts[0] + " " + hint + " " + parts[1]
+}
+
+// StatementRewriter rewrites a parsed SQL statement before DoFilter sends it
+// to MySQL. Rewriters run in registration order; each sees the output of the
+// previous one. changed reports whether this call actually mutated stmt, so
+// runRewriters can skip re-rendering (and thereby reformatting) statements
+// no registered rewriter touched.
+type StatementRewriter interface {
+	Rewrite(ctx context.Context, stmt ParsedStmt) (newStmt ParsedStmt, changed bool, err error)
+}
+
+// Use registers rewriters that DoFilter runs, in order, against every
+// statement the SQL parser accepts. It is not safe to call concurrently
+// with DoFilter.
+func (d *Driver) Use(rewriters ...StatementRewriter) {
+	d.rewriters = append(d.rewriters, rewriters...)
+}
+
+// runRewriters parses sql and passes it through the registered rewriter
+// pipeline. Statements the parser rejects (vendor-specific syntax, session
+// commands, ...) are passed through unmodified rather than failing the
+// query, since the pipeline is an optimization, not a validator. sql itself
+// is returned verbatim, without going through sqlparser.String, unless a
+// rewriter actually reports a change, so statements no rewriter touches
+// aren't silently reformatted (keyword casing, quoting, whitespace).
+func (d *Driver) runRewriters(ctx context.Context, link gdb.Link, sql string) (string, error) {
+	if len(d.rewriters) == 0 {
+		return sql, nil
+	}
+
+	parsed, err := sqlparser.Parse(sql)
+	if err != nil {
+		return sql, nil
+	}
+
+	ctx = context.WithValue(ctx, ctxKeyInTransaction, link.IsTransaction())
+
+	var (
+		mutated bool
+		changed bool
+	)
+	stmt := ParsedStmt{Statement: parsed}
+	for _, rewriter := range d.rewriters {
+		stmt, changed, err = rewriter.Rewrite(ctx, stmt)
+		if err != nil {
+			return "", err
+		}
+		mutated = mutated || changed
+	}
+
+	if !mutated {
+		return sql, nil
+	}
+
+	return stmt.String(), nil
+}
+
+// isWriteStatement reports whether stmt must be pinned to the primary
+// regardless of read-preference routing: it mutates data or schema, is a
+// locking read, sets session state, or controls a transaction.
+func isWriteStatement(stmt sqlparser.Statement) bool {
+	switch s := stmt.(type) {
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete,
+		*sqlparser.Set, *sqlparser.Begin, *sqlparser.Commit, *sqlparser.Rollback,
+		sqlparser.DDLStatement:
+		return true
+	case *sqlparser.Select:
+		return s.Lock != sqlparser.NoLock
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// ForceIndexRewriter adds a `FORCE INDEX` hint to every reference to a
+// configured table, keyed by unqualified table name. References that
+// already carry index hints are left as they are.
+type ForceIndexRewriter struct {
+	indexByTable map[string]string
+}
+
+// NewForceIndexRewriter returns a StatementRewriter that forces the given
+// index for every reference to its table.
+func NewForceIndexRewriter(indexByTable map[string]string) *ForceIndexRewriter {
+	return &ForceIndexRewriter{indexByTable: indexByTable}
+}
+
+// Rewrite implements StatementRewriter.
+func (r *ForceIndexRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	if len(r.indexByTable) == 0 {
+		return stmt, false, nil
+	}
+
+	var changed bool
+	rewritten := sqlparser.Rewrite(stmt.Statement, func(cursor *sqlparser.Cursor) bool {
+		aliased, ok := cursor.Node().(*sqlparser.AliasedTableExpr)
+		if !ok || aliased.Hints != nil {
+			return true
+		}
+		tableName, ok := aliased.Expr.(sqlparser.TableName)
+		if !ok {
+			return true
+		}
+		index, ok := r.indexByTable[tableName.Name.String()]
+		if !ok {
+			return true
+		}
+		aliased.Hints = sqlparser.IndexHints{{
+			Type:    sqlparser.ForceOp,
+			Indexes: []sqlparser.IdentifierCI{sqlparser.NewIdentifierCI(index)},
+		}}
+		changed = true
+		return true
+	}, nil)
+
+	stmt.Statement = rewritten.(sqlparser.Statement)
+	return stmt, changed, nil
+}
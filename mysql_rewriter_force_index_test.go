@@ -0,0 +1,48 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestForceIndexRewriterAddsHintForConfiguredTable(t *testing.T) {
+	r := NewForceIndexRewriter(map[string]string{"users": "idx_users_email"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users WHERE email = 'x'")
+	if !changed {
+		t.Fatalf("expected change for a configured table")
+	}
+	if want := "select * from users force index (idx_users_email) where email = 'x'"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestForceIndexRewriterNoopForUnconfiguredTable(t *testing.T) {
+	r := NewForceIndexRewriter(map[string]string{"users": "idx_users_email"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM orders")
+	if changed {
+		t.Fatalf("expected no change for a table with no configured index")
+	}
+	if want := "select * from orders"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestForceIndexRewriterNoopWhenEmpty(t *testing.T) {
+	r := NewForceIndexRewriter(nil)
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users")
+	if changed {
+		t.Fatalf("expected no change with no configured tables")
+	}
+	if want := "select * from users"; got != want {
+		t.Errorf("got %q, want statement returned unmodified %q", got, want)
+	}
+}
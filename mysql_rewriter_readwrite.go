@@ -0,0 +1,31 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import "context"
+
+// ReadWriteHintRewriter annotates statements with a ProxySQL-compatible
+// `read_from` routing hint based on the statement's inferred access type.
+// Writes (INSERT/UPDATE/DELETE/REPLACE/DDL, and SELECT ... FOR UPDATE) are
+// always pinned to the primary; read routing preferences are layered on top
+// by mysql.WithReadPreference.
+type ReadWriteHintRewriter struct{}
+
+// NewReadWriteHintRewriter returns a StatementRewriter that hints read/write
+// routing for ProxySQL-style connection pools.
+func NewReadWriteHintRewriter() *ReadWriteHintRewriter {
+	return &ReadWriteHintRewriter{}
+}
+
+// Rewrite implements StatementRewriter.
+func (r *ReadWriteHintRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	if !isWriteStatement(stmt.Statement) {
+		return stmt, false, nil
+	}
+	stmt.Hints = append(stmt.Hints, "read_from=primary")
+	return stmt, true, nil
+}
@@ -0,0 +1,36 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestReadWriteHintRewriterPinsWritesToPrimary(t *testing.T) {
+	r := NewReadWriteHintRewriter()
+
+	got, changed := rewriteSql(t, context.Background(), r, "UPDATE users SET active = 0")
+	if !changed {
+		t.Fatalf("expected change for a write statement")
+	}
+	if want := "update /*+ read_from=primary */ users set active = 0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadWriteHintRewriterLeavesReadsUnhinted(t *testing.T) {
+	r := NewReadWriteHintRewriter()
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users")
+	if changed {
+		t.Fatalf("expected no change for a read statement")
+	}
+	if want := "select * from users"; got != want {
+		t.Errorf("got %q, want statement returned unmodified %q", got, want)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// SoftDeleteRewriter appends a `<column> IS NULL` predicate to the WHERE
+// clause of SELECT/UPDATE/DELETE statements for every configured table,
+// keyed by unqualified table name, so soft-deleted rows stay invisible
+// without every call site remembering to filter them.
+type SoftDeleteRewriter struct {
+	columnByTable map[string]string
+}
+
+// NewSoftDeleteRewriter returns a StatementRewriter that filters out
+// soft-deleted rows for the given tables. columnByTable maps an unqualified
+// table name to the column that marks a row deleted (commonly "deleted_at").
+func NewSoftDeleteRewriter(columnByTable map[string]string) *SoftDeleteRewriter {
+	return &SoftDeleteRewriter{columnByTable: columnByTable}
+}
+
+// Rewrite implements StatementRewriter.
+func (r *SoftDeleteRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	if len(r.columnByTable) == 0 {
+		return stmt, false, nil
+	}
+
+	var changed bool
+	switch s := stmt.Statement.(type) {
+	case *sqlparser.Select:
+		s.Where, changed = r.apply(s.From, s.Where)
+	case *sqlparser.Update:
+		s.Where, changed = r.apply(s.TableExprs, s.Where)
+	case *sqlparser.Delete:
+		s.Where, changed = r.apply(s.TableExprs, s.Where)
+	}
+
+	return stmt, changed, nil
+}
+
+// apply builds the conjunction of soft-delete predicates for every
+// configured table in exprs and ANDs it onto where, reporting whether it
+// added anything.
+func (r *SoftDeleteRewriter) apply(exprs sqlparser.TableExprs, where *sqlparser.Where) (*sqlparser.Where, bool) {
+	var predicate sqlparser.Expr
+	for _, expr := range exprs {
+		predicate = r.applyExpr(expr, predicate)
+	}
+
+	if predicate == nil {
+		return where, false
+	}
+	if where == nil {
+		return &sqlparser.Where{Type: sqlparser.WhereClause, Expr: predicate}, true
+	}
+	where.Expr = andExpr(where.Expr, predicate)
+	return where, true
+}
+
+// applyExpr ANDs a soft-delete predicate for expr onto predicate, recursing
+// into JoinTableExpr/ParenTableExpr so every configured table in a joined or
+// parenthesized FROM/USING list is covered, not just its first level.
+func (r *SoftDeleteRewriter) applyExpr(expr sqlparser.TableExpr, predicate sqlparser.Expr) sqlparser.Expr {
+	switch e := expr.(type) {
+	case *sqlparser.AliasedTableExpr:
+		tableName, ok := e.Expr.(sqlparser.TableName)
+		if !ok {
+			return predicate
+		}
+		column, ok := r.columnByTable[tableName.Name.String()]
+		if !ok {
+			return predicate
+		}
+		return andExpr(predicate, &sqlparser.IsExpr{
+			Left: &sqlparser.ColName{
+				Name:      sqlparser.NewIdentifierCI(column),
+				Qualifier: tableRef(e, tableName),
+			},
+			Right: sqlparser.IsNullOp,
+		})
+	case *sqlparser.JoinTableExpr:
+		predicate = r.applyExpr(e.LeftExpr, predicate)
+		predicate = r.applyExpr(e.RightExpr, predicate)
+		return predicate
+	case *sqlparser.ParenTableExpr:
+		for _, inner := range e.Exprs {
+			predicate = r.applyExpr(inner, predicate)
+		}
+		return predicate
+	default:
+		return predicate
+	}
+}
+
+// tableRef returns the table qualifier a column reference should use: the
+// table's alias if it has one, otherwise its own name.
+func tableRef(aliased *sqlparser.AliasedTableExpr, tableName sqlparser.TableName) sqlparser.TableName {
+	if !aliased.As.IsEmpty() {
+		return sqlparser.TableName{Name: sqlparser.NewIdentifierCS(aliased.As.String())}
+	}
+	return tableName
+}
+
+// andExpr ANDs b onto a, treating a nil a as "no predicate yet".
+func andExpr(a, b sqlparser.Expr) sqlparser.Expr {
+	if a == nil {
+		return b
+	}
+	return &sqlparser.AndExpr{Left: a, Right: b}
+}
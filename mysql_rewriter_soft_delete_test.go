@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSoftDeleteRewriterAppendsPredicateToSelect(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users")
+	if !changed {
+		t.Fatalf("expected change for a configured table")
+	}
+	if want := "select * from users where users.deleted_at is null"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSoftDeleteRewriterAndsOntoExistingWhere(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users WHERE active = 1")
+	if !changed {
+		t.Fatalf("expected change for a configured table")
+	}
+	want := "select * from users where active = 1 and users.deleted_at is null"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSoftDeleteRewriterHandlesUpdateAndDelete(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at"})
+
+	gotUpdate, changed := rewriteSql(t, context.Background(), r, "UPDATE users SET active = 0")
+	if !changed {
+		t.Fatalf("expected change for UPDATE on a configured table")
+	}
+	if want := "update users set active = 0 where users.deleted_at is null"; gotUpdate != want {
+		t.Errorf("got %q, want %q", gotUpdate, want)
+	}
+
+	gotDelete, changed := rewriteSql(t, context.Background(), r, "DELETE FROM users")
+	if !changed {
+		t.Fatalf("expected change for DELETE on a configured table")
+	}
+	if want := "delete from users where users.deleted_at is null"; gotDelete != want {
+		t.Errorf("got %q, want %q", gotDelete, want)
+	}
+}
+
+func TestSoftDeleteRewriterUsesTableAlias(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users u")
+	if !changed {
+		t.Fatalf("expected change for a configured table")
+	}
+	if want := "select * from users as u where u.deleted_at is null"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSoftDeleteRewriterCoversBothSidesOfAJoin(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at", "posts": "deleted_at"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM users u JOIN posts p ON p.user_id = u.id")
+	if !changed {
+		t.Fatalf("expected change for configured tables on both sides of the join")
+	}
+	want := "select * from users as u join posts as p on p.user_id = u.id where u.deleted_at is null and p.deleted_at is null"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSoftDeleteRewriterNoopForUnconfiguredTable(t *testing.T) {
+	r := NewSoftDeleteRewriter(map[string]string{"users": "deleted_at"})
+
+	got, changed := rewriteSql(t, context.Background(), r, "SELECT * FROM orders")
+	if changed {
+		t.Fatalf("expected no change for a table with no configured soft-delete column")
+	}
+	if want := "select * from orders"; got != want {
+		t.Errorf("got %q, want statement returned unmodified %q", got, want)
+	}
+}
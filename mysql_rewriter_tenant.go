@@ -0,0 +1,87 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// TenantRewriter qualifies every unqualified table reference in a statement
+// with the tenant database set on the context via the "tenant_database"
+// key, so a single connection pool can serve many per-tenant schemas. It
+// replaces the earlier comment-only tenant_db hint: ProxySQL-style comments
+// only route the connection, they don't change which schema `table` resolves
+// to once the query lands on a shared MySQL instance.
+//
+// CTE names are left unqualified, since they name a result set defined in
+// the same statement rather than a physical table.
+type TenantRewriter struct{}
+
+// NewTenantRewriter returns a StatementRewriter that qualifies table
+// references with the tenant database found on the context.
+func NewTenantRewriter() *TenantRewriter {
+	return &TenantRewriter{}
+}
+
+// Rewrite implements StatementRewriter.
+func (r *TenantRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	tenantDB, ok := ctx.Value(ctxKeyTenantDatabase).(string)
+	if !ok || tenantDB == "" {
+		return stmt, false, nil
+	}
+
+	ctes := cteNames(stmt.Statement)
+
+	var changed bool
+	rewritten := sqlparser.Rewrite(stmt.Statement, func(cursor *sqlparser.Cursor) bool {
+		// Match only real table positions (AliasedTableExpr.Expr, which also
+		// covers Insert.Table). A bare sqlparser.TableName also appears as
+		// ColName.Qualifier, i.e. a table *alias* like the "u" in
+		// "u.id" — qualifying that would rewrite an alias reference into a
+		// bogus schema-qualified one and produce invalid SQL.
+		aliased, ok := cursor.Node().(*sqlparser.AliasedTableExpr)
+		if !ok {
+			return true
+		}
+		tableName, ok := aliased.Expr.(sqlparser.TableName)
+		if !ok {
+			return true
+		}
+		if !tableName.Qualifier.IsEmpty() || tableName.Name.IsEmpty() {
+			return true
+		}
+		if ctes[tableName.Name.String()] {
+			return true
+		}
+		aliased.Expr = sqlparser.TableName{
+			Name:      tableName.Name,
+			Qualifier: sqlparser.NewIdentifierCS(tenantDB),
+		}
+		changed = true
+		return true
+	}, nil)
+
+	stmt.Statement = rewritten.(sqlparser.Statement)
+	return stmt, changed, nil
+}
+
+// cteNames collects the names a statement's WITH clause binds, across the
+// statement kinds that support one (SELECT, UPDATE, DELETE). CommonTableExpr
+// isn't reachable through exported fields, so this walks the statement like
+// any other rewriter would.
+func cteNames(stmt sqlparser.Statement) map[string]bool {
+	names := make(map[string]bool)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if cte, ok := node.(*sqlparser.CommonTableExpr); ok {
+			names[cte.ID.String()] = true
+		}
+		return true, nil
+	}, stmt)
+	return names
+}
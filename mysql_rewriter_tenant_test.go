@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+func rewriteSql(t *testing.T, ctx context.Context, r StatementRewriter, sql string) (string, bool) {
+	t.Helper()
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		t.Fatalf("parse(%q): %v", sql, err)
+	}
+	out, changed, err := r.Rewrite(ctx, ParsedStmt{Statement: stmt})
+	if err != nil {
+		t.Fatalf("Rewrite(%q): %v", sql, err)
+	}
+	return out.String(), changed
+}
+
+func TestTenantRewriterQualifiesUnqualifiedTables(t *testing.T) {
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	got, changed := rewriteSql(t, ctx, NewTenantRewriter(), "SELECT * FROM users WHERE id = 1")
+
+	if !changed {
+		t.Fatalf("expected change when tenant database is set")
+	}
+	if want := "select * from acme.users where id = 1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTenantRewriterLeavesAlreadyQualifiedTables(t *testing.T) {
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	got, changed := rewriteSql(t, ctx, NewTenantRewriter(), "SELECT * FROM other.users WHERE id = 1")
+
+	if changed {
+		t.Fatalf("already-qualified table should not count as a change")
+	}
+	if want := "select * from other.users where id = 1"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTenantRewriterLeavesCteNamesUnqualified(t *testing.T) {
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	got, changed := rewriteSql(t, ctx, NewTenantRewriter(),
+		"WITH recent AS (SELECT id FROM orders) SELECT * FROM recent")
+
+	if !changed {
+		t.Fatalf("expected change from qualifying `orders`")
+	}
+	want := "with recent as (select id from acme.orders) select * from recent"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTenantRewriterLeavesTableAliasesUnqualified(t *testing.T) {
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	got, changed := rewriteSql(t, ctx, NewTenantRewriter(), "SELECT u.id FROM users AS u WHERE u.id = 1")
+
+	if !changed {
+		t.Fatalf("expected change from qualifying `users`")
+	}
+	want := "select u.id from acme.users as u where u.id = 1"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTenantRewriterQualifiesBothSidesOfAJoin(t *testing.T) {
+	ctx := WithTenantDatabase(context.Background(), "acme")
+	got, changed := rewriteSql(t, ctx, NewTenantRewriter(),
+		"SELECT * FROM users u JOIN orders o ON o.uid = u.id")
+
+	if !changed {
+		t.Fatalf("expected change from qualifying `users` and `orders`")
+	}
+	want := "select * from acme.users as u join acme.orders as o on o.uid = u.id"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTenantRewriterNoopWithoutTenantOnContext(t *testing.T) {
+	got, changed := rewriteSql(t, context.Background(), NewTenantRewriter(), "SELECT * FROM users")
+	if changed {
+		t.Fatalf("expected no change without a tenant database on ctx")
+	}
+	if want := "select * from users"; got != want {
+		t.Errorf("got %q, want statement returned unmodified %q", got, want)
+	}
+}
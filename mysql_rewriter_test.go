@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+)
+
+// noopLink is a minimal gdb.Link for exercising runRewriters without a real
+// connection. label distinguishes otherwise-identical instances, e.g. when
+// asserting ReplicaGroup.next rotates between distinct links.
+type noopLink struct {
+	label         string
+	inTransaction bool
+}
+
+func (noopLink) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (noopLink) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+func (noopLink) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, nil }
+func (noopLink) IsOnMaster() bool                                          { return true }
+func (l noopLink) IsTransaction() bool                                     { return l.inTransaction }
+
+// noopRewriter leaves stmt untouched and reports its configured changed
+// value, for exercising runRewriters' mutation gating independent of any
+// real rewriter's rules.
+type noopRewriter struct{ changed bool }
+
+func (r noopRewriter) Rewrite(ctx context.Context, stmt ParsedStmt) (ParsedStmt, bool, error) {
+	return stmt, r.changed, nil
+}
+
+func TestIsWriteStatement(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want bool
+	}{
+		{"SELECT * FROM t", false},
+		{"SELECT * FROM t FOR UPDATE", true},
+		{"INSERT INTO t (a) VALUES (1)", true},
+		{"UPDATE t SET a = 1", true},
+		{"DELETE FROM t", true},
+		{"CREATE TABLE t (id INT)", true},
+	}
+
+	for _, c := range cases {
+		stmt, err := sqlparser.Parse(c.sql)
+		if err != nil {
+			t.Fatalf("parse(%q): %v", c.sql, err)
+		}
+		if got := isWriteStatement(stmt); got != c.want {
+			t.Errorf("isWriteStatement(%q) = %v, want %v", c.sql, got, c.want)
+		}
+	}
+}
+
+func TestParsedStmtStringAppliesHints(t *testing.T) {
+	stmt, err := sqlparser.Parse("SELECT 1 FROM t")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	p := ParsedStmt{Statement: stmt}
+	if got, want := p.String(), "select 1 from t"; got != want {
+		t.Fatalf("String() with no hints = %q, want %q", got, want)
+	}
+
+	p.Hints = []string{"read_from=primary"}
+	got := p.String()
+	want := "select /*+ read_from=primary */ 1 from t"
+	if got != want {
+		t.Errorf("String() with hints = %q, want %q", got, want)
+	}
+}
+
+func TestRunRewritersReturnsOriginalSqlWhenNothingChanged(t *testing.T) {
+	d := &Driver{}
+	d.Use(noopRewriter{changed: false})
+
+	const in = "SELECT 1 FROM t"
+	got, err := d.runRewriters(context.Background(), noopLink{}, in)
+	if err != nil {
+		t.Fatalf("runRewriters: %v", err)
+	}
+	if got != in {
+		t.Errorf("runRewriters() = %q, want original %q unchanged", got, in)
+	}
+}
+
+func TestRunRewritersReRendersWhenChanged(t *testing.T) {
+	d := &Driver{}
+	d.Use(noopRewriter{changed: true})
+
+	got, err := d.runRewriters(context.Background(), noopLink{}, "SELECT 1 FROM t")
+	if err != nil {
+		t.Fatalf("runRewriters: %v", err)
+	}
+	if want := "select 1 from t"; got != want {
+		t.Errorf("runRewriters() = %q, want %q", got, want)
+	}
+}
+
+func TestRunRewritersPassesThroughUnparseableStatements(t *testing.T) {
+	d := &Driver{}
+	d.Use(noopRewriter{changed: true})
+
+	const in = "THIS IS NOT VALID SQL ((("
+	got, err := d.runRewriters(context.Background(), noopLink{}, in)
+	if err != nil {
+		t.Fatalf("runRewriters: %v", err)
+	}
+	if got != in {
+		t.Errorf("runRewriters() = %q, want passthrough %q", got, in)
+	}
+}
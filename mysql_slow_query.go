@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SlowQuery is a single entry in a SlowQueryTracker's per-tenant top-N.
+type SlowQuery struct {
+	Digest  string
+	Elapsed time.Duration
+}
+
+// SlowQueryTracker keeps the N slowest distinct digests observed per
+// tenant, so operators can see which statements dominate load without
+// turning on the general log. It implements MetricsSink, forwarding every
+// observation to an optional wrapped sink.
+type SlowQueryTracker struct {
+	topN int
+	next MetricsSink
+
+	mu       sync.Mutex
+	byTenant map[string][]SlowQuery
+}
+
+// NewSlowQueryTracker returns a MetricsSink that tracks the topN slowest
+// digests per tenant. next may be nil; otherwise every observation is
+// forwarded to it first.
+func NewSlowQueryTracker(topN int, next MetricsSink) *SlowQueryTracker {
+	return &SlowQueryTracker{
+		topN:     topN,
+		next:     next,
+		byTenant: make(map[string][]SlowQuery),
+	}
+}
+
+// Observe implements MetricsSink.
+func (t *SlowQueryTracker) Observe(digest, tenant string, elapsed time.Duration, rows int64, err error) {
+	if t.next != nil {
+		t.next.Observe(digest, tenant, elapsed, rows, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.byTenant[tenant] = upsertSlowQuery(t.byTenant[tenant], SlowQuery{Digest: digest, Elapsed: elapsed}, t.topN)
+}
+
+// Top returns tenant's slowest tracked digests, slowest first.
+func (t *SlowQueryTracker) Top(tenant string) []SlowQuery {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make([]SlowQuery, len(t.byTenant[tenant]))
+	copy(out, t.byTenant[tenant])
+	return out
+}
+
+// upsertSlowQuery inserts or updates q in entries, keeping it sorted slowest
+// first and truncated to topN.
+func upsertSlowQuery(entries []SlowQuery, q SlowQuery, topN int) []SlowQuery {
+	for i, e := range entries {
+		if e.Digest == q.Digest {
+			if q.Elapsed > e.Elapsed {
+				entries[i].Elapsed = q.Elapsed
+			}
+			sortSlowQueries(entries)
+			return entries
+		}
+	}
+
+	entries = append(entries, q)
+	sortSlowQueries(entries)
+	if len(entries) > topN {
+		entries = entries[:topN]
+	}
+	return entries
+}
+
+func sortSlowQueries(entries []SlowQuery) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Elapsed > entries[j].Elapsed })
+}
@@ -0,0 +1,27 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mysql
+
+import "context"
+
+// ctxKeyTenantDatabase is the context key DoFilter, TenantRewriter, and
+// replica routing all read to find which tenant a statement belongs to.
+const ctxKeyTenantDatabase = "tenant_database"
+
+// WithTenantDatabase attaches the tenant database a statement should be
+// routed to: DoFilter reads it back for ProxySQL tenant_db tags, replica
+// routing for per-tenant replica groups, and (when registered) TenantRewriter
+// to qualify table references.
+func WithTenantDatabase(ctx context.Context, tenantDB string) context.Context {
+	return context.WithValue(ctx, ctxKeyTenantDatabase, tenantDB)
+}
+
+// TenantDatabaseFromContext returns the tenant database set on ctx, if any.
+func TenantDatabaseFromContext(ctx context.Context) (string, bool) {
+	tenantDB, ok := ctx.Value(ctxKeyTenantDatabase).(string)
+	return tenantDB, ok
+}